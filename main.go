@@ -96,23 +96,6 @@ func SortStudentsForChineseMathEnglish(students []Student) []Student {
 	return sortedStudents
 }
 
-// getFlagPriority 获取标识的优先级，用于排序
-// 规则：0 < 西 < 1 < 2
-func getFlagPriority(flag string) int {
-	switch flag {
-	case "0":
-		return 0
-	case "西":
-		return 1
-	case "1":
-		return 2
-	case "2":
-		return 3
-	default:
-		return 4 // 其他标识值排在最后
-	}
-}
-
 // CalculateRank 计算学生排名
 func CalculateRank(students []Student) []Student {
 	// 复制切片以避免修改原数据
@@ -147,72 +130,29 @@ func AssignChineseMathEnglish(students []Student) []Student {
 	return sortedStudents
 }
 
-// 主函数
-func main() {
-	// 读取CSV文件
-	students, err := readCSVFile("students.csv")
-	if err != nil {
-		fmt.Printf("读取文件失败: %v\n", err)
-		return
-	}
-
-	// 首先对学生进行排序，用于计算排名（不考虑"西"的影响）
-	rankSortedStudents := SortStudentsForRank(students)
-
+// assignAllSubjects 对语数外、物理、化学、生物、政治、历史、地理字段进行赋值，
+// 是整个排名流水线中"赋值"这一步的统一入口，供批处理模式和 Store.Rebuild 共用。
+// 物理/化学/生物/政治/历史/地理六科通过 pipeline 并发完成，语数外的赋值逻辑与
+// 选科关键字无关，单独串行执行。
+func assignAllSubjects(sourceStudents []Student, rankedStudents []Student) []Student {
 	// 根据排名顺序对语数外字段进行赋值（考虑"西"的影响）
-	assignedStudents := AssignChineseMathEnglish(students)
-
-	// 对物理字段进行赋值（根据选科是否包含"物"字）
-	physicsAssignedStudents := AssignPhysics(students)
+	assignedStudents := AssignChineseMathEnglish(sourceStudents)
 
-	// 对化学字段进行赋值（根据选科是否包含"化"字）
-	chemistryAssignedStudents := AssignChemistry(students)
-
-	// 对生物字段进行赋值（根据选科是否包含"生"字）
-	biologyAssignedStudents := AssignBiology(students)
-
-	// 对政治字段进行赋值（根据选科是否包含"政"字）
-	politicsAssignedStudents := AssignPolitics(students)
-
-	// 对历史字段进行赋值（根据选科是否包含"史"字）
-	historyAssignedStudents := AssignHistory(students)
-
-	// 对地理字段进行赋值（根据选科是否包含"地"字）
-	geographyAssignedStudents := AssignGeography(students)
+	// 物理/化学/生物/政治/历史/地理六科通过并发流水线赋值
+	subjectResults := assignSubjectsConcurrently(sourceStudents)
 
 	// 创建映射，用于快速查找每个学生的值
 	chineseMathEnglishMap := make(map[string]int) // key: 考号
-	physicsMap := make(map[string]string) // key: 考号
-	chemistryMap := make(map[string]string) // key: 考号
-	biologyMap := make(map[string]string) // key: 考号
-	politicsMap := make(map[string]string) // key: 考号
-	historyMap := make(map[string]string) // key: 考号
-	geographyMap := make(map[string]string) // key: 考号
+	physicsMap := subjectResults["物"]
+	chemistryMap := subjectResults["化"]
+	biologyMap := subjectResults["生"]
+	politicsMap := subjectResults["政"]
+	historyMap := subjectResults["史"]
+	geographyMap := subjectResults["地"]
 
 	for _, student := range assignedStudents {
 		chineseMathEnglishMap[student.ExamID] = student.ChineseMathEnglish
 	}
-	for _, student := range physicsAssignedStudents {
-		physicsMap[student.ExamID] = student.Physics
-	}
-	for _, student := range chemistryAssignedStudents {
-		chemistryMap[student.ExamID] = student.Chemistry
-	}
-	for _, student := range biologyAssignedStudents {
-		biologyMap[student.ExamID] = student.Biology
-	}
-	for _, student := range politicsAssignedStudents {
-		politicsMap[student.ExamID] = student.Politics
-	}
-	for _, student := range historyAssignedStudents {
-		historyMap[student.ExamID] = student.History
-	}
-	for _, student := range geographyAssignedStudents {
-		geographyMap[student.ExamID] = student.Geography
-	}
-
-	// 重新计算排名
-	rankedStudents := CalculateRank(rankSortedStudents)
 
 	// 将语数外、物理、化学、生物、政治、历史、地理值赋值给排名后的学生
 	for i := range rankedStudents {
@@ -239,11 +179,55 @@ func main() {
 		}
 	}
 
-	// 打印学生信息
-	for _, student := range rankedStudents {
+	return rankedStudents
+}
+
+// runPipeline 对一份学生名单跑完整的排名/赋值流水线，返回最终结果
+func runPipeline(students []Student) []Student {
+	rankSortedStudents := SortStudentsForRank(students)
+	rankedStudents := CalculateRank(rankSortedStudents)
+	return assignAllSubjects(students, rankedStudents)
+}
+
+// printStudents 打印学生信息（沿用批处理模式下的输出格式）
+func printStudents(students []Student) {
+	for _, student := range students {
 		fmt.Printf("序号: %d, 选科: %s, 考号: %s, 学生姓名: %s, 班级: %s, 总分: %d, 排名: %d, 语数外: %d, 物理: %s, 化学: %s, 生物: %s, 政治: %s, 历史: %s, 地理: %s, 标识: %s\n",
 			student.ID, student.Subjects, student.ExamID, student.Name, student.Class, student.TotalScore, student.Rank, student.ChineseMathEnglish, student.Physics, student.Chemistry, student.Biology, student.Politics, student.History, student.Geography, student.Flag)
 	}
+}
+
+// 主函数
+func main() {
+	// 加载选科/标识/选科组合等配置，文件不存在时沿用内置的默认配置
+	if err := LoadActiveSchema("schema.json"); err != nil {
+		fmt.Printf("加载schema配置失败: %v\n", err)
+		return
+	}
+
+	// 读取CSV文件
+	students, err := readCSVFile("students.csv")
+	if err != nil {
+		fmt.Printf("读取文件失败: %v\n", err)
+		return
+	}
+
+	// 校验选科代码是否都在配置的学科范围内、选科组合是否是配置中的合法组合，
+	// 以及（如果启用）新高考"3+1+2"模式的选科是否合规
+	for _, problem := range ValidateSubjectCodes(students) {
+		fmt.Println("选科校验警告:", problem)
+	}
+	for _, problem := range ValidateSubjectCombinations(students) {
+		fmt.Println("选科组合校验警告:", problem)
+	}
+	for _, problem := range ValidateNewGaokaoSelection(students) {
+		fmt.Println("新高考选科校验警告:", problem)
+	}
+
+	rankedStudents := runPipeline(students)
+
+	// 打印学生信息
+	printStudents(rankedStudents)
 
 	// 分析选科组合
 	analyzeSubjectCombinations(rankedStudents)
@@ -257,6 +241,10 @@ func main() {
 		return
 	}
 	fmt.Println("\n结果已写入到 result.csv 文件中")
+
+	// 进入交互式命令行，支持对学生信息库进行增删改查和统计
+	store := NewStore(runPipeline(students), "students.csv")
+	runREPL(store)
 }
 
 // parseInt 解析整数，处理空字符串情况
@@ -268,76 +256,6 @@ func parseInt(s string) int {
 	return val
 }
 
-// readCSVFile 读取CSV文件并返回学生信息列表
-func readCSVFile(filePath string) ([]Student, error) {
-	// 打开文件
-	file, err := os.Open(filePath)
-	if err != nil {
-		return nil, err
-	}
-	defer file.Close()
-
-	// 创建CSV读取器
-	reader := csv.NewReader(file)
-
-	// 读取表头
-	header, err := reader.Read()
-	if err != nil {
-		return nil, err
-	}
-
-	// 验证表头
-	expectedHeader := []string{"序号", "选科", "考号", "学生姓名", "班级", "总分", "排名", "语数外", "物理", "化学", "生物", "政治", "历史", "地理", "标识"}
-	if len(header) != len(expectedHeader) {
-		return nil, fmt.Errorf("表头长度不匹配，期望 %d 列，实际 %d 列", len(expectedHeader), len(header))
-	}
-
-	// 读取数据行
-	var students []Student
-	for {
-		row, err := reader.Read()
-		if err != nil {
-			break
-		}
-
-		// 解析数据
-		id := parseInt(row[0])
-		totalScore := parseInt(row[5])
-		rank := parseInt(row[6])
-		chineseMathEnglish := parseInt(row[7]) // 解析语数外列的整数值
-		physics := row[8] // 直接读取物理列的字符串值
-		chemistry := row[9] // 直接读取化学列的字符串值
-		biology := row[10] // 直接读取生物列的字符串值
-		politics := row[11] // 直接读取政治列的字符串值
-		history := row[12] // 直接读取历史列的字符串值
-		geography := row[13] // 直接读取地理列的字符串值
-		flag := row[14] // 直接读取标识列的字符串值，包括"西"
-
-		// 创建学生对象
-		student := Student{
-			ID:                  id,
-			Subjects:            row[1],
-			ExamID:              row[2],
-			Name:                row[3],
-			Class:               row[4],
-			TotalScore:          totalScore,
-			Rank:                rank,
-			ChineseMathEnglish:  chineseMathEnglish,
-			Physics:             physics,
-			Chemistry:           chemistry,
-			Biology:             biology,
-			Politics:            politics,
-			History:             history,
-			Geography:           geography,
-			Flag:                flag,
-		}
-
-		students = append(students, student)
-	}
-
-	return students, nil
-}
-
 // analyzeSubjectCombinations 分析选科组合
 func analyzeSubjectCombinations(students []Student) {
 	// 统计各选科组合的学生数量
@@ -368,205 +286,6 @@ func analyzeFlags(students []Student) {
 	}
 }
 
-// AssignSubject 根据选科是否包含指定关键字对学科字段进行赋值
-// 规则：1. 选科包含关键字的学生，字段直接赋值为数字（如1, 2, 3...），超过10时继续递增
-//      2. 选科不包含关键字的学生，字段赋值为自习+数字，从包含关键字的学生数量+1开始（如自习11, 自习12, 自习13...）
-//      3. 排序时，先按标识优先级（0 < 1 < 2）排序，再按总分降序排序
-func AssignSubject(students []Student, keyword string) map[string]string {
-	// 分离包含关键字和不包含关键字的学生
-	var keywordStudents, nonKeywordStudents []Student
-	for _, student := range students {
-		if containsString(student.Subjects, keyword) {
-			keywordStudents = append(keywordStudents, student)
-		} else {
-			nonKeywordStudents = append(nonKeywordStudents, student)
-		}
-	}
-
-	// 对包含关键字的学生排序：先按标识优先级（0 < 1 < 2），再按总分降序
-	sort.Slice(keywordStudents, func(i, j int) bool {
-		// 首先比较标识优先级
-		flagPriorityI := getSubjectFlagPriority(keywordStudents[i].Flag)
-		flagPriorityJ := getSubjectFlagPriority(keywordStudents[j].Flag)
-		if flagPriorityI != flagPriorityJ {
-			return flagPriorityI < flagPriorityJ
-		}
-		
-		// 标识优先级相同的情况下，按总分降序排序
-		if keywordStudents[i].TotalScore != keywordStudents[j].TotalScore {
-			return keywordStudents[i].TotalScore > keywordStudents[j].TotalScore
-		}
-		
-		// 总分相同的情况下，按ID升序排序（确保稳定性）
-		return keywordStudents[i].ID < keywordStudents[j].ID
-	})
-
-	// 对不包含关键字的学生排序：先按标识优先级（0 < 1 < 2），再按总分降序
-	sort.Slice(nonKeywordStudents, func(i, j int) bool {
-		// 首先比较标识优先级
-		flagPriorityI := getSubjectFlagPriority(nonKeywordStudents[i].Flag)
-		flagPriorityJ := getSubjectFlagPriority(nonKeywordStudents[j].Flag)
-		if flagPriorityI != flagPriorityJ {
-			return flagPriorityI < flagPriorityJ
-		}
-		
-		// 标识优先级相同的情况下，按总分降序排序
-		if nonKeywordStudents[i].TotalScore != nonKeywordStudents[j].TotalScore {
-			return nonKeywordStudents[i].TotalScore > nonKeywordStudents[j].TotalScore
-		}
-		
-		// 总分相同的情况下，按ID升序排序（确保稳定性）
-		return nonKeywordStudents[i].ID < nonKeywordStudents[j].ID
-	})
-
-	// 创建映射，用于快速查找每个学生的学科值
-	subjectMap := make(map[string]string) // key: 考号
-
-	// 为包含关键字的学生赋值，从1开始递增
-	for i, student := range keywordStudents {
-		score := i + 1
-		subjectMap[student.ExamID] = strconv.Itoa(score)
-	}
-
-	// 为不包含关键字的学生赋值，从包含关键字的学生数量+1开始
-	keywordCount := len(keywordStudents)
-	for i, student := range nonKeywordStudents {
-		score := keywordCount + i + 1
-		subjectMap[student.ExamID] = "自习" + strconv.Itoa(score)
-	}
-
-	return subjectMap
-}
-
-// getSubjectFlagPriority 获取学科排序时标识的优先级
-// 规则：西=0 < 1 < 2，其他标识值排在最后
-func getSubjectFlagPriority(flag string) int {
-	switch flag {
-	case "0", "西":
-		return 0
-	case "1":
-		return 1
-	case "2":
-		return 2
-	default:
-		return 3 // 其他标识值排在最后
-	}
-}
-
-// AssignPhysics 根据选科是否包含"物"字对物理字段进行赋值
-func AssignPhysics(students []Student) []Student {
-	// 复制切片以避免修改原数据
-	assignedStudents := make([]Student, len(students))
-	copy(assignedStudents, students)
-
-	// 获取物理值映射
-	physicsMap := AssignSubject(students, "物")
-
-	// 将物理值赋值给学生
-	for i := range assignedStudents {
-		if physics, ok := physicsMap[assignedStudents[i].ExamID]; ok {
-			assignedStudents[i].Physics = physics
-		}
-	}
-
-	return assignedStudents
-}
-
-// AssignChemistry 根据选科是否包含"化"字对化学字段进行赋值
-func AssignChemistry(students []Student) []Student {
-	// 复制切片以避免修改原数据
-	assignedStudents := make([]Student, len(students))
-	copy(assignedStudents, students)
-
-	// 获取化学值映射
-	chemistryMap := AssignSubject(students, "化")
-
-	// 将化学值赋值给学生
-	for i := range assignedStudents {
-		if chemistry, ok := chemistryMap[assignedStudents[i].ExamID]; ok {
-			assignedStudents[i].Chemistry = chemistry
-		}
-	}
-
-	return assignedStudents
-}
-
-// AssignBiology 根据选科是否包含"生"字对生物字段进行赋值
-func AssignBiology(students []Student) []Student {
-	// 复制切片以避免修改原数据
-	assignedStudents := make([]Student, len(students))
-	copy(assignedStudents, students)
-
-	// 获取生物值映射
-	biologyMap := AssignSubject(students, "生")
-
-	// 将生物值赋值给学生
-	for i := range assignedStudents {
-		if biology, ok := biologyMap[assignedStudents[i].ExamID]; ok {
-			assignedStudents[i].Biology = biology
-		}
-	}
-
-	return assignedStudents
-}
-
-// AssignPolitics 根据选科是否包含"政"字对政治字段进行赋值
-func AssignPolitics(students []Student) []Student {
-	// 复制切片以避免修改原数据
-	assignedStudents := make([]Student, len(students))
-	copy(assignedStudents, students)
-
-	// 获取政治值映射
-	politicsMap := AssignSubject(students, "政")
-
-	// 将政治值赋值给学生
-	for i := range assignedStudents {
-		if politics, ok := politicsMap[assignedStudents[i].ExamID]; ok {
-			assignedStudents[i].Politics = politics
-		}
-	}
-
-	return assignedStudents
-}
-
-// AssignHistory 根据选科是否包含"史"字对历史字段进行赋值
-func AssignHistory(students []Student) []Student {
-	// 复制切片以避免修改原数据
-	assignedStudents := make([]Student, len(students))
-	copy(assignedStudents, students)
-
-	// 获取历史值映射
-	historyMap := AssignSubject(students, "史")
-
-	// 将历史值赋值给学生
-	for i := range assignedStudents {
-		if history, ok := historyMap[assignedStudents[i].ExamID]; ok {
-			assignedStudents[i].History = history
-		}
-	}
-
-	return assignedStudents
-}
-
-// AssignGeography 根据选科是否包含"地"字对地理字段进行赋值
-func AssignGeography(students []Student) []Student {
-	// 复制切片以避免修改原数据
-	assignedStudents := make([]Student, len(students))
-	copy(assignedStudents, students)
-
-	// 获取地理值映射
-	geographyMap := AssignSubject(students, "地")
-
-	// 将地理值赋值给学生
-	for i := range assignedStudents {
-		if geography, ok := geographyMap[assignedStudents[i].ExamID]; ok {
-			assignedStudents[i].Geography = geography
-		}
-	}
-
-	return assignedStudents
-}
-
 // containsString 检查字符串是否包含指定子串
 func containsString(s, substr string) bool {
 	for i := 0; i <= len(s)-len(substr); i++ {
@@ -598,27 +317,32 @@ func writeResultFile(students []Student, filePath string) error {
 
 	// 写入数据行
 	for _, student := range students {
-		row := []string{
-			strconv.Itoa(student.ID),
-			student.Subjects,
-			student.ExamID,
-			student.Name,
-			student.Class,
-			strconv.Itoa(student.TotalScore),
-			strconv.Itoa(student.Rank),
-			strconv.Itoa(student.ChineseMathEnglish),
-			student.Physics,
-			student.Chemistry,
-			student.Biology,
-			student.Politics,
-			student.History,
-			student.Geography,
-			student.Flag,
-		}
-		if err := writer.Write(row); err != nil {
+		if err := writer.Write(studentRow(student)); err != nil {
 			return err
 		}
 	}
 
 	return nil
 }
+
+// studentRow 把一个 Student 转换成写入CSV所需的字符串切片，列顺序与 expectedHeader 一致，
+// writeResultFile 和流式的 RunCountingSortRanking 共用同一份转换逻辑
+func studentRow(student Student) []string {
+	return []string{
+		strconv.Itoa(student.ID),
+		student.Subjects,
+		student.ExamID,
+		student.Name,
+		student.Class,
+		strconv.Itoa(student.TotalScore),
+		strconv.Itoa(student.Rank),
+		strconv.Itoa(student.ChineseMathEnglish),
+		student.Physics,
+		student.Chemistry,
+		student.Biology,
+		student.Politics,
+		student.History,
+		student.Geography,
+		student.Flag,
+	}
+}
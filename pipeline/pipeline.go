@@ -0,0 +1,81 @@
+// Package pipeline 提供一个并发的"按关键字分组赋值"框架，
+// 用于把物理/化学/生物/政治/历史/地理等学科列
+// 这类按选科关键字赋值的流程改造成并发执行，并让新增学科（如"技术"、"音乐"）
+// 无需改动调用方代码即可接入。
+package pipeline
+
+import "sync"
+
+// Student 是调用方学生结构体中流水线关心的子集：
+// 考号用于在 fan-in 阶段合并结果，选科用于判断该生是否命中某个关键字，
+// 总分/标识/序号则供赋值函数按照各自的排序规则使用（例如先按标识优先级、再按总分降序）。
+type Student struct {
+	ID         int
+	ExamID     string
+	Subjects   string
+	TotalScore int
+	Flag       string
+}
+
+// AssignerFunc 接受调用方传给 Run 的全部学生（按原始顺序，不预先按关键字筛选），
+// 返回 考号 -> 赋值结果 的映射。main 包里的 subjectAssigner 就是这样一个函数的典型实现。
+type AssignerFunc func(students []Student) map[string]string
+
+// registration 记录一个学科关键字与其对应的赋值函数
+type registration struct {
+	keyword  string
+	assigner AssignerFunc
+}
+
+// Pipeline 管理一组 (关键字, 赋值函数) 对，并发地对每个学科执行赋值，
+// 再把所有学科的结果汇总成 学科 -> (考号 -> 值) 的结果表。
+type Pipeline struct {
+	registrations []registration
+}
+
+// New 创建一个空的 Pipeline
+func New() *Pipeline {
+	return &Pipeline{}
+}
+
+// Register 注册一个学科关键字及其赋值函数，后续 Run 会为它单独起一个 goroutine
+func (p *Pipeline) Register(keyword string, assigner AssignerFunc) {
+	p.registrations = append(p.registrations, registration{keyword: keyword, assigner: assigner})
+}
+
+// subjectResult 是单个学科 goroutine 产出的结果，通过 channel 发回 fan-in 的 merger goroutine
+type subjectResult struct {
+	keyword string
+	values  map[string]string
+}
+
+// Run 并发执行所有已注册学科的赋值：每个学科一个 goroutine 消费 students，
+// 产出 考号 -> 值 的映射并发到结果 channel；一个 merger goroutine 通过 WaitGroup 等待
+// 所有学科完成后关闭该 channel，Run 自身从 channel 逐个取出结果，汇总为
+// 学科 -> (考号 -> 值) 的最终结果。
+func (p *Pipeline) Run(students []Student) map[string]map[string]string {
+	results := make(chan subjectResult, len(p.registrations))
+
+	var wg sync.WaitGroup
+	for _, reg := range p.registrations {
+		wg.Add(1)
+		go func(reg registration) {
+			defer wg.Done()
+			results <- subjectResult{keyword: reg.keyword, values: reg.assigner(students)}
+		}(reg)
+	}
+
+	// merger goroutine：等待全部学科 goroutine 完成后关闭 channel，
+	// 让下面的 range 能够正常结束
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	merged := make(map[string]map[string]string, len(p.registrations))
+	for result := range results {
+		merged[result.keyword] = result.values
+	}
+
+	return merged
+}
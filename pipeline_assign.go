@@ -0,0 +1,82 @@
+package main
+
+import (
+	"sort"
+	"strconv"
+
+	"student-ranking-system/pipeline"
+)
+
+// toPipelineStudents 把 main.Student 转换成流水线关心的最小字段集合
+func toPipelineStudents(students []Student) []pipeline.Student {
+	pipelineStudents := make([]pipeline.Student, len(students))
+	for i, student := range students {
+		pipelineStudents[i] = pipeline.Student{
+			ID:         student.ID,
+			ExamID:     student.ExamID,
+			Subjects:   student.Subjects,
+			TotalScore: student.TotalScore,
+			Flag:       student.Flag,
+		}
+	}
+	return pipelineStudents
+}
+
+// subjectAssigner 返回一个按 keyword 赋值的 pipeline.AssignerFunc，
+// 排序/赋值规则与重构前的串行赋值逻辑一致：先分组，再按标识优先级、总分降序、ID升序排序，
+// 命中关键字的学生从1开始编号，未命中的学生接在后面以"自习"+编号赋值。
+func subjectAssigner(keyword string) pipeline.AssignerFunc {
+	return func(students []pipeline.Student) map[string]string {
+		var keywordStudents, nonKeywordStudents []pipeline.Student
+		for _, student := range students {
+			if containsString(student.Subjects, keyword) {
+				keywordStudents = append(keywordStudents, student)
+			} else {
+				nonKeywordStudents = append(nonKeywordStudents, student)
+			}
+		}
+
+		sortPipelineStudents(keywordStudents)
+		sortPipelineStudents(nonKeywordStudents)
+
+		subjectMap := make(map[string]string)
+		for i, student := range keywordStudents {
+			subjectMap[student.ExamID] = strconv.Itoa(i + 1)
+		}
+
+		keywordCount := len(keywordStudents)
+		for i, student := range nonKeywordStudents {
+			subjectMap[student.ExamID] = activeSchema.SelfStudyPlaceholder + strconv.Itoa(keywordCount+i+1)
+		}
+
+		return subjectMap
+	}
+}
+
+// sortPipelineStudents 按标识优先级（0/西 < 1 < 2）、总分降序、ID升序排序
+func sortPipelineStudents(students []pipeline.Student) {
+	sort.Slice(students, func(i, j int) bool {
+		flagPriorityI := getSubjectFlagPriority(students[i].Flag)
+		flagPriorityJ := getSubjectFlagPriority(students[j].Flag)
+		if flagPriorityI != flagPriorityJ {
+			return flagPriorityI < flagPriorityJ
+		}
+
+		if students[i].TotalScore != students[j].TotalScore {
+			return students[i].TotalScore > students[j].TotalScore
+		}
+
+		return students[i].ID < students[j].ID
+	})
+}
+
+// assignSubjectsConcurrently 并发跑完 activeSchema.SubjectKeywords 中每个学科的赋值，
+// 返回 学科关键字 -> (考号 -> 赋值结果) 的结果表
+func assignSubjectsConcurrently(students []Student) map[string]map[string]string {
+	p := pipeline.New()
+	for _, keyword := range activeSchema.SubjectKeywords {
+		p.Register(keyword, subjectAssigner(keyword))
+	}
+
+	return p.Run(toPipelineStudents(students))
+}
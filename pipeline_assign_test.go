@@ -0,0 +1,86 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+// generateTestStudents 确定性地生成 n 条测试学生数据，选科/标识/总分都按下标循环取值，
+// 不依赖随机数，保证测试和基准可重复
+func generateTestStudents(n int) []Student {
+	combinations := []string{"物化生", "物化地", "物化政", "政史地", "政生史", "政生地", "史地化", "物化史", "物政生"}
+	flags := []string{"0", "西", "1", "2"}
+
+	students := make([]Student, n)
+	for i := 0; i < n; i++ {
+		students[i] = Student{
+			ID:         i + 1,
+			Subjects:   combinations[i%len(combinations)],
+			ExamID:     "E" + string(rune('A'+i%26)) + string(rune('0'+(i/26)%10)) + string(rune('0'+(i/260)%10)),
+			Name:       "学生" + string(rune('A'+i%26)),
+			Class:      "班级" + string(rune('1'+i%6)),
+			TotalScore: 300 + (i*37)%500,
+			Flag:       flags[i%len(flags)],
+		}
+	}
+	return students
+}
+
+// serialAssignSubjects 把 activeSchema.SubjectKeywords 中的每个学科依次（非并发）
+// 交给 subjectAssigner 处理，作为并发流水线的对照组
+func serialAssignSubjects(students []Student) map[string]map[string]string {
+	pipelineStudents := toPipelineStudents(students)
+
+	results := make(map[string]map[string]string, len(activeSchema.SubjectKeywords))
+	for _, keyword := range activeSchema.SubjectKeywords {
+		results[keyword] = subjectAssigner(keyword)(pipelineStudents)
+	}
+	return results
+}
+
+// TestAssignSubjectsConcurrentlyMatchesSerial 验证并发流水线的 fan-in 结果
+// 与逐个学科串行执行的结果完全一致
+func TestAssignSubjectsConcurrentlyMatchesSerial(t *testing.T) {
+	students := generateTestStudents(500)
+
+	concurrentResult := assignSubjectsConcurrently(students)
+	serialResult := serialAssignSubjects(students)
+
+	if !reflect.DeepEqual(concurrentResult, serialResult) {
+		t.Fatalf("并发流水线结果与串行结果不一致\n并发: %v\n串行: %v", concurrentResult, serialResult)
+	}
+}
+
+// TestAssignSubjectsConcurrentlyEmpty 验证空输入下并发流水线也能正常返回
+func TestAssignSubjectsConcurrentlyEmpty(t *testing.T) {
+	result := assignSubjectsConcurrently(nil)
+	if len(result) != len(activeSchema.SubjectKeywords) {
+		t.Fatalf("期望返回 %d 个学科的结果，实际返回 %d 个", len(activeSchema.SubjectKeywords), len(result))
+	}
+	for _, keyword := range activeSchema.SubjectKeywords {
+		if len(result[keyword]) != 0 {
+			t.Fatalf("学科 %s 在空输入下应该没有任何赋值", keyword)
+		}
+	}
+}
+
+// BenchmarkAssignSubjectsConcurrently 衡量并发流水线在万级学生规模下的耗时
+func BenchmarkAssignSubjectsConcurrently(b *testing.B) {
+	students := generateTestStudents(10000)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		assignSubjectsConcurrently(students)
+	}
+}
+
+// BenchmarkAssignSubjectsSerial 衡量逐个学科串行执行在相同规模下的耗时，
+// 用于和 BenchmarkAssignSubjectsConcurrently 对比并发带来的加速
+func BenchmarkAssignSubjectsSerial(b *testing.B) {
+	students := generateTestStudents(10000)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		serialAssignSubjects(students)
+	}
+}
@@ -0,0 +1,179 @@
+package main
+
+import "sort"
+
+// RankingStrategy 决定同分学生之间的并列处理规则。SortStudentsForRank 仍然负责
+// 按总分降序（ID升序作为稳定性兜底）排序，各策略只负责在排好序的结果上给 Rank 字段赋值，
+// 这样 CalculateRank 原有的调用方式保持不变，只是把"怎么编号"这一步换成可插拔的实现。
+type RankingStrategy interface {
+	AssignRanks(sortedStudents []Student) []Student
+}
+
+// OrdinalRankingStrategy 是 CalculateRank 当前使用的规则：不管是否并列，
+// 按排序后的位置连续编号（1,2,3,4...）
+type OrdinalRankingStrategy struct{}
+
+// AssignRanks 按排序位置连续编号
+func (OrdinalRankingStrategy) AssignRanks(sortedStudents []Student) []Student {
+	ranked := make([]Student, len(sortedStudents))
+	copy(ranked, sortedStudents)
+
+	for i := range ranked {
+		ranked[i].Rank = i + 1
+	}
+	return ranked
+}
+
+// DenseRankingStrategy 实现密集排名：并列学生排名相同，下一个不同总分紧接着递增（1,2,2,3）
+type DenseRankingStrategy struct{}
+
+// AssignRanks 按密集排名规则编号
+func (DenseRankingStrategy) AssignRanks(sortedStudents []Student) []Student {
+	ranked := make([]Student, len(sortedStudents))
+	copy(ranked, sortedStudents)
+
+	rank := 0
+	for i := range ranked {
+		if i == 0 || ranked[i].TotalScore != ranked[i-1].TotalScore {
+			rank++
+		}
+		ranked[i].Rank = rank
+	}
+	return ranked
+}
+
+// StandardCompetitionRankingStrategy 实现标准竞赛排名：并列学生排名相同，
+// 之后的名次跳过被占用的位次（1,2,2,4）
+type StandardCompetitionRankingStrategy struct{}
+
+// AssignRanks 按标准竞赛排名规则编号
+func (StandardCompetitionRankingStrategy) AssignRanks(sortedStudents []Student) []Student {
+	ranked := make([]Student, len(sortedStudents))
+	copy(ranked, sortedStudents)
+
+	for i := range ranked {
+		if i > 0 && ranked[i].TotalScore == ranked[i-1].TotalScore {
+			ranked[i].Rank = ranked[i-1].Rank
+		} else {
+			ranked[i].Rank = i + 1
+		}
+	}
+	return ranked
+}
+
+// FractionalRankingStrategy 实现分数排名：并列学生取所占名次区间的平均值
+// （例如并列第2、3名都记为2.5）。Student.Rank 是整数字段，这里四舍五入取整。
+type FractionalRankingStrategy struct{}
+
+// AssignRanks 按分数排名规则编号，并列区间取平均名次（四舍五入）
+func (FractionalRankingStrategy) AssignRanks(sortedStudents []Student) []Student {
+	ranked := make([]Student, len(sortedStudents))
+	copy(ranked, sortedStudents)
+
+	for i := 0; i < len(ranked); {
+		j := i
+		for j < len(ranked) && ranked[j].TotalScore == ranked[i].TotalScore {
+			j++
+		}
+
+		// [i, j) 是并列区间，对应名次 i+1 ... j，取平均值并四舍五入
+		count := j - i
+		sumOfPositions := 0
+		for position := i + 1; position <= j; position++ {
+			sumOfPositions += position
+		}
+		averageRank := (sumOfPositions*2 + count) / (2 * count) // 四舍五入的整数除法
+
+		for k := i; k < j; k++ {
+			ranked[k].Rank = averageRank
+		}
+		i = j
+	}
+
+	return ranked
+}
+
+// CalculateRankWithStrategy 是 CalculateRank 的可插拔版本：
+// 先用 SortStudentsForRank 排好序，再交给 strategy 决定并列名次怎么编号。
+func CalculateRankWithStrategy(students []Student, strategy RankingStrategy) []Student {
+	sortedStudents := SortStudentsForRank(students)
+	return strategy.AssignRanks(sortedStudents)
+}
+
+// PointsTableStrategy 实现 F1 积分榜式排名：把每场考试看作一场比赛，
+// 按名次发放 Points 中配置的积分（如 []int{25,18,15,12,10,8,6,4,2,1}），
+// 多场考试按考号累加积分后，再按总积分降序得到最终排名。
+// 用于对一系列月考进行综合排名，而不是单次考试的名次。
+type PointsTableStrategy struct {
+	Points []int // 名次 -> 积分，名次超出该切片长度时记0分
+}
+
+// pointsForRank 返回某个名次能拿到的积分，超出配置范围记0分
+func (p PointsTableStrategy) pointsForRank(rank int) int {
+	if rank < 1 || rank > len(p.Points) {
+		return 0
+	}
+	return p.Points[rank-1]
+}
+
+// AccumulateAcrossRaces 把多场考试（每场是一份学生总分列表）的积分按考号累加，
+// 返回 考号 -> 累计积分 的映射
+func (p PointsTableStrategy) AccumulateAcrossRaces(races [][]Student) map[string]int {
+	totals := make(map[string]int)
+
+	for _, race := range races {
+		ranked := CalculateRankWithStrategy(race, OrdinalRankingStrategy{})
+		for _, student := range ranked {
+			totals[student.ExamID] += p.pointsForRank(student.Rank)
+		}
+	}
+
+	return totals
+}
+
+// RankByPoints 根据多场考试的累计积分对学生进行最终排名（积分降序，同分按ID升序）。
+// 积分榜的名单是所有 races 里出现过的考号的并集，而不只是 students（调用方通常传入的
+// 最近一场考试名单）——这样即使某个学生在更早的考试里拿过积分，之后又转学/被删除、
+// 不在最新名单里，也不会从积分榜上消失。仍在 students 里的学生，姓名/班级等展示信息
+// 以 students 为准；只在历史考试里出现过的学生，展示信息取自他们最后一次出现的那场
+// 考试。排名结果的 Rank 字段是积分榜名次，TotalScore 字段被复用为累计积分。
+func (p PointsTableStrategy) RankByPoints(students []Student, races [][]Student) []Student {
+	totals := p.AccumulateAcrossRaces(races)
+
+	rosterByExamID := make(map[string]Student)
+	order := make([]string, 0)
+	for _, race := range races {
+		for _, student := range race {
+			if _, exists := rosterByExamID[student.ExamID]; !exists {
+				order = append(order, student.ExamID)
+			}
+			rosterByExamID[student.ExamID] = student
+		}
+	}
+	for _, student := range students {
+		if _, exists := rosterByExamID[student.ExamID]; !exists {
+			order = append(order, student.ExamID)
+		}
+		rosterByExamID[student.ExamID] = student
+	}
+
+	ranked := make([]Student, 0, len(order))
+	for _, examID := range order {
+		ranked = append(ranked, rosterByExamID[examID])
+	}
+
+	sort.Slice(ranked, func(i, j int) bool {
+		pointsI, pointsJ := totals[ranked[i].ExamID], totals[ranked[j].ExamID]
+		if pointsI != pointsJ {
+			return pointsI > pointsJ
+		}
+		return ranked[i].ID < ranked[j].ID
+	})
+
+	for i := range ranked {
+		ranked[i].TotalScore = totals[ranked[i].ExamID]
+		ranked[i].Rank = i + 1
+	}
+
+	return ranked
+}
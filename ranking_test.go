@@ -0,0 +1,161 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+// sortedByScore 构造一组已经按总分降序排好的学生（AssignRanks 假定输入已经是这个顺序）
+func sortedByScore(scores ...int) []Student {
+	students := make([]Student, len(scores))
+	for i, score := range scores {
+		students[i] = Student{ID: i + 1, TotalScore: score}
+	}
+	return students
+}
+
+func ranksOf(students []Student) []int {
+	ranks := make([]int, len(students))
+	for i, student := range students {
+		ranks[i] = student.Rank
+	}
+	return ranks
+}
+
+// TestDenseRankingStrategy 验证密集排名：并列学生排名相同，下一个不同总分紧接着递增（1,2,2,3）
+func TestDenseRankingStrategy(t *testing.T) {
+	students := sortedByScore(100, 90, 90, 80)
+	ranked := DenseRankingStrategy{}.AssignRanks(students)
+
+	want := []int{1, 2, 2, 3}
+	if got := ranksOf(ranked); !reflect.DeepEqual(got, want) {
+		t.Fatalf("密集排名结果错误，期望 %v，实际 %v", want, got)
+	}
+}
+
+// TestStandardCompetitionRankingStrategy 验证标准竞赛排名：并列学生排名相同，
+// 之后的名次跳过被占用的位次（1,2,2,4）
+func TestStandardCompetitionRankingStrategy(t *testing.T) {
+	students := sortedByScore(100, 90, 90, 80)
+	ranked := StandardCompetitionRankingStrategy{}.AssignRanks(students)
+
+	want := []int{1, 2, 2, 4}
+	if got := ranksOf(ranked); !reflect.DeepEqual(got, want) {
+		t.Fatalf("标准竞赛排名结果错误，期望 %v，实际 %v", want, got)
+	}
+}
+
+// TestFractionalRankingStrategyExactAverage 验证三人并列时平均名次刚好是整数的情况（(2+3+4)/3=3）
+func TestFractionalRankingStrategyExactAverage(t *testing.T) {
+	students := sortedByScore(100, 90, 90, 90, 70)
+	ranked := FractionalRankingStrategy{}.AssignRanks(students)
+
+	want := []int{1, 3, 3, 3, 5}
+	if got := ranksOf(ranked); !reflect.DeepEqual(got, want) {
+		t.Fatalf("分数排名结果错误，期望 %v，实际 %v", want, got)
+	}
+}
+
+// TestFractionalRankingStrategyRoundsHalfUp 验证两人并列时平均名次是 x.5 的情况按四舍五入（向上）取整：
+// 并列第2、3名，平均名次2.5，取整后记为3
+func TestFractionalRankingStrategyRoundsHalfUp(t *testing.T) {
+	students := sortedByScore(100, 90, 90, 80)
+	ranked := FractionalRankingStrategy{}.AssignRanks(students)
+
+	want := []int{1, 3, 3, 4}
+	if got := ranksOf(ranked); !reflect.DeepEqual(got, want) {
+		t.Fatalf("分数排名四舍五入结果错误，期望 %v，实际 %v", want, got)
+	}
+}
+
+// TestPointsTableStrategyAccumulateAcrossRaces 验证多场考试的积分按考号正确累加
+func TestPointsTableStrategyAccumulateAcrossRaces(t *testing.T) {
+	race1 := []Student{
+		{ID: 1, ExamID: "A", Name: "甲", TotalScore: 90},
+		{ID: 2, ExamID: "B", Name: "乙", TotalScore: 95},
+		{ID: 3, ExamID: "C", Name: "丙", TotalScore: 80},
+	}
+	race2 := []Student{
+		{ID: 1, ExamID: "A", Name: "甲", TotalScore: 100},
+		{ID: 2, ExamID: "B", Name: "乙", TotalScore: 85},
+		{ID: 3, ExamID: "C", Name: "丙", TotalScore: 90},
+	}
+
+	strategy := PointsTableStrategy{Points: []int{25, 18, 15}}
+	totals := strategy.AccumulateAcrossRaces([][]Student{race1, race2})
+
+	// race1 名次: B(95)=1st->25, A(90)=2nd->18, C(80)=3rd->15
+	// race2 名次: A(100)=1st->25, C(90)=2nd->18, B(85)=3rd->15
+	want := map[string]int{
+		"A": 18 + 25, // 43
+		"B": 25 + 15, // 40
+		"C": 15 + 18, // 33
+	}
+	if !reflect.DeepEqual(totals, want) {
+		t.Fatalf("积分累加结果错误，期望 %v，实际 %v", want, totals)
+	}
+}
+
+// TestPointsTableStrategyRankByPoints 验证最终积分榜按累计积分降序排列，
+// 并且 Rank/TotalScore 字段分别被写成积分榜名次和累计积分
+func TestPointsTableStrategyRankByPoints(t *testing.T) {
+	race1 := []Student{
+		{ID: 1, ExamID: "A", Name: "甲", TotalScore: 90},
+		{ID: 2, ExamID: "B", Name: "乙", TotalScore: 95},
+		{ID: 3, ExamID: "C", Name: "丙", TotalScore: 80},
+	}
+	race2 := []Student{
+		{ID: 1, ExamID: "A", Name: "甲", TotalScore: 100},
+		{ID: 2, ExamID: "B", Name: "乙", TotalScore: 85},
+		{ID: 3, ExamID: "C", Name: "丙", TotalScore: 90},
+	}
+
+	strategy := PointsTableStrategy{Points: []int{25, 18, 15}}
+	ranked := strategy.RankByPoints(race2, [][]Student{race1, race2})
+
+	wantOrder := []string{"A", "B", "C"}
+	wantPoints := []int{43, 40, 33}
+	for i, student := range ranked {
+		if student.ExamID != wantOrder[i] {
+			t.Fatalf("第%d名应该是考号 %s，实际是 %s", i+1, wantOrder[i], student.ExamID)
+		}
+		if student.TotalScore != wantPoints[i] {
+			t.Fatalf("考号 %s 的累计积分应该是 %d，实际是 %d", student.ExamID, wantPoints[i], student.TotalScore)
+		}
+		if student.Rank != i+1 {
+			t.Fatalf("考号 %s 的积分榜名次应该是 %d，实际是 %d", student.ExamID, i+1, student.Rank)
+		}
+	}
+}
+
+// TestPointsTableStrategyRankByPointsKeepsDroppedStudent 验证某个学生在较早的考试里拿过积分，
+// 但已经不在当前名单（students）里了（转学/被删除），积分榜仍然要保留这个学生
+func TestPointsTableStrategyRankByPointsKeepsDroppedStudent(t *testing.T) {
+	race1 := []Student{
+		{ID: 1, ExamID: "A", Name: "甲", TotalScore: 100},
+		{ID: 2, ExamID: "B", Name: "乙", TotalScore: 90},
+	}
+	race2 := []Student{
+		{ID: 2, ExamID: "B", Name: "乙", TotalScore: 90},
+	}
+
+	strategy := PointsTableStrategy{Points: []int{25, 18}}
+	ranked := strategy.RankByPoints(race2, [][]Student{race1, race2})
+
+	if len(ranked) != 2 {
+		t.Fatalf("已经不在当前名单里但拿过积分的学生不应该从积分榜消失，期望 2 条记录，实际 %d 条: %+v", len(ranked), ranked)
+	}
+
+	found := false
+	for _, student := range ranked {
+		if student.ExamID == "A" {
+			found = true
+			if student.TotalScore != 25 {
+				t.Fatalf("考号 A 的累计积分应该是 25，实际是 %d", student.TotalScore)
+			}
+		}
+	}
+	if !found {
+		t.Fatalf("积分榜里应该包含已退出当前名单的考号 A，实际: %+v", ranked)
+	}
+}
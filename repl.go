@@ -0,0 +1,345 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// runREPL 启动一个简单的交互式命令行，让本工具可以当作一个真正的学生信息管理器使用，
+// 而不只是一次性的批处理脚本。支持的命令：help、add、update、del、find、stats、export、
+// rank、points、rankbig、quit/exit
+func runREPL(store *Store) {
+	fmt.Println("\n已进入交互模式，输入 help 查看可用命令")
+
+	scanner := bufio.NewScanner(os.Stdin)
+	for {
+		fmt.Print("> ")
+		if !scanner.Scan() {
+			return
+		}
+
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+
+		command, args := fields[0], fields[1:]
+		switch command {
+		case "help":
+			printREPLHelp()
+		case "add":
+			handleAdd(store, args)
+		case "update":
+			handleUpdate(store, args)
+		case "del":
+			handleDel(store, args)
+		case "find":
+			handleFind(store, args)
+		case "stats":
+			handleStats(store)
+		case "export":
+			handleExport(store, args)
+		case "rank":
+			handleRank(store, args)
+		case "points":
+			handlePoints(store, args)
+		case "rankbig":
+			handleRankBig(store, args)
+		case "quit", "exit":
+			return
+		default:
+			fmt.Printf("未知命令: %s，输入 help 查看可用命令\n", command)
+		}
+	}
+}
+
+// printREPLHelp 打印命令说明
+func printREPLHelp() {
+	fmt.Println(`可用命令:
+  add <考号> <姓名> <班级> <选科> <总分> <标识>  新增一条学生记录
+  update <考号> <字段> <新值>                    修改一条学生记录，字段可选 examid/name/class/subjects/totalscore/flag
+  del <考号>                                    删除指定考号的学生
+  find examid <考号>                            按考号精确查找
+  find name <姓名>                              按姓名查找（可能有重名）
+  find class <班级>                             按班级查找
+  stats                                          打印班级/学科统计、每班前三名及班级内排名
+  export <文件名>                                将当前名单重新跑一遍流水线并导出
+  rank <ordinal|dense|standard|fractional>      切换并列排名规则并重新计算排名
+  points <考试csv文件>...                        把当前名单和历史考试按F1积分规则合并，打印积分榜
+  rankbig <输入csv文件> <输出csv文件>             流式对大文件做两遍计数排序并直接写出，全程不会把输入一次性装进内存
+  quit                                           退出交互模式`)
+}
+
+// handleAdd 处理 add 命令：add <考号> <姓名> <班级> <选科> <总分> <标识>
+func handleAdd(store *Store, args []string) {
+	if len(args) != 6 {
+		fmt.Println("用法: add <考号> <姓名> <班级> <选科> <总分> <标识>")
+		return
+	}
+
+	totalScore, err := strconv.Atoi(args[4])
+	if err != nil {
+		fmt.Printf("总分必须是整数: %v\n", err)
+		return
+	}
+
+	student := Student{
+		ID:         store.NextID(),
+		ExamID:     args[0],
+		Name:       args[1],
+		Class:      args[2],
+		Subjects:   args[3],
+		TotalScore: totalScore,
+		Flag:       args[5],
+	}
+
+	if err := store.Insert(student); err != nil {
+		fmt.Printf("新增失败: %v\n", err)
+		return
+	}
+
+	store.Rebuild()
+	fmt.Printf("已新增考号 %s 的学生，排名已重新计算\n", student.ExamID)
+}
+
+// handleUpdate 处理 update 命令：update <考号> <字段> <新值>
+// 字段可选 examid/name/class/subjects/totalscore/flag，修改考号（examid）时
+// Store.Update 会自动把索引迁移到新考号上
+func handleUpdate(store *Store, args []string) {
+	if len(args) != 3 {
+		fmt.Println("用法: update <考号> <字段> <新值>，字段可选 examid/name/class/subjects/totalscore/flag")
+		return
+	}
+
+	examID, field, value := args[0], args[1], args[2]
+
+	validFields := map[string]bool{"examid": true, "name": true, "class": true, "subjects": true, "totalscore": true, "flag": true}
+	if !validFields[field] {
+		fmt.Println("字段必须是 examid/name/class/subjects/totalscore/flag 之一")
+		return
+	}
+
+	var totalScore int
+	if field == "totalscore" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			fmt.Printf("总分必须是整数: %v\n", err)
+			return
+		}
+		totalScore = parsed
+	}
+
+	err := store.Update(examID, func(student *Student) {
+		switch field {
+		case "examid":
+			student.ExamID = value
+		case "name":
+			student.Name = value
+		case "class":
+			student.Class = value
+		case "subjects":
+			student.Subjects = value
+		case "totalscore":
+			student.TotalScore = totalScore
+		case "flag":
+			student.Flag = value
+		}
+	})
+	if err != nil {
+		fmt.Printf("更新失败: %v\n", err)
+		return
+	}
+
+	store.Rebuild()
+	fmt.Printf("已更新考号 %s 的学生，排名已重新计算\n", examID)
+}
+
+// handleDel 处理 del 命令：del <考号>
+func handleDel(store *Store, args []string) {
+	if len(args) != 1 {
+		fmt.Println("用法: del <考号>")
+		return
+	}
+
+	if err := store.Delete(args[0]); err != nil {
+		fmt.Printf("删除失败: %v\n", err)
+		return
+	}
+
+	store.Rebuild()
+	fmt.Printf("已删除考号 %s 的学生，排名已重新计算\n", args[0])
+}
+
+// handleFind 处理 find 命令：find examid|name|class <关键字>
+func handleFind(store *Store, args []string) {
+	if len(args) != 2 {
+		fmt.Println("用法: find examid|name|class <关键字>")
+		return
+	}
+
+	mode, key := args[0], args[1]
+	var results []Student
+
+	switch mode {
+	case "examid":
+		if student, ok := store.FindByExamID(key); ok {
+			results = []Student{student}
+		}
+	case "name":
+		results = store.FindByName(key)
+	case "class":
+		results = store.FindByClass(key)
+	default:
+		fmt.Println("查找方式必须是 examid、name 或 class 之一")
+		return
+	}
+
+	if len(results) == 0 {
+		fmt.Println("未找到匹配的学生")
+		return
+	}
+	printStudents(results)
+}
+
+// handleStats 处理 stats 命令：打印班级统计、学科统计、每班前三名及班级内排名
+func handleStats(store *Store) {
+	students := store.Students()
+
+	fmt.Println("\n班级统计:")
+	for _, stat := range analyzeClassStats(students) {
+		fmt.Printf("班级 %s: 人数 %d, 平均分 %.2f, 最高分 %d(%s), 最低分 %d(%s)\n",
+			stat.Class, stat.Count, stat.Average, stat.Highest, stat.HighestName, stat.Lowest, stat.LowestName)
+	}
+
+	fmt.Println("\n学科统计:")
+	for _, stat := range analyzeSubjectStats(students) {
+		if stat.Count == 0 {
+			continue
+		}
+		fmt.Printf("学科 %s: 选科人数 %d, 平均分 %.2f, 最高分 %d(%s), 最低分 %d(%s)\n",
+			stat.Subject, stat.Count, stat.Average, stat.Highest, stat.HighestName, stat.Lowest, stat.LowestName)
+	}
+
+	fmt.Println("\n每个班级前三名:")
+	for class, top := range topNPerClass(students, 3) {
+		fmt.Printf("班级 %s:\n", class)
+		for i, student := range top {
+			fmt.Printf("  第%d名 %s 总分 %d\n", i+1, student.Name, student.TotalScore)
+		}
+	}
+
+	fmt.Println("\n班级内排名:")
+	classRanks := classRankWithinGrade(students)
+	for class, group := range topNPerClass(students, len(students)) {
+		fmt.Printf("班级 %s:\n", class)
+		for _, student := range group {
+			fmt.Printf("  班级排名第%d名 %s（考号 %s）总分 %d\n", classRanks[student.ExamID], student.Name, student.ExamID, student.TotalScore)
+		}
+	}
+}
+
+// handleExport 处理 export 命令：export <文件名>
+func handleExport(store *Store, args []string) {
+	if len(args) != 1 {
+		fmt.Println("用法: export <文件名>")
+		return
+	}
+
+	ranked := runPipeline(store.Students())
+	if err := writeResultFile(ranked, args[0]); err != nil {
+		fmt.Printf("导出失败: %v\n", err)
+		return
+	}
+	fmt.Printf("已导出到 %s\n", args[0])
+}
+
+// rankingStrategyByName 把命令行里的名字映射到对应的 RankingStrategy 实现
+func rankingStrategyByName(name string) (RankingStrategy, bool) {
+	switch name {
+	case "ordinal":
+		return OrdinalRankingStrategy{}, true
+	case "dense":
+		return DenseRankingStrategy{}, true
+	case "standard":
+		return StandardCompetitionRankingStrategy{}, true
+	case "fractional":
+		return FractionalRankingStrategy{}, true
+	default:
+		return nil, false
+	}
+}
+
+// handleRank 处理 rank 命令：rank <ordinal|dense|standard|fractional>
+// 切换 Store 在 Rebuild 时使用的并列排名规则，并立即重新计算一次排名
+func handleRank(store *Store, args []string) {
+	if len(args) != 1 {
+		fmt.Println("用法: rank <ordinal|dense|standard|fractional>")
+		return
+	}
+
+	strategy, ok := rankingStrategyByName(args[0])
+	if !ok {
+		fmt.Println("排名规则必须是 ordinal、dense、standard 或 fractional 之一")
+		return
+	}
+
+	store.SetRankingStrategy(strategy)
+	ranked := store.Rebuild()
+
+	fmt.Printf("已切换为 %s 排名规则，重新计算后的排名:\n", args[0])
+	printStudents(ranked)
+}
+
+// defaultF1Points 是 points 命令使用的默认积分表，对应前10名的F1积分规则
+var defaultF1Points = []int{25, 18, 15, 12, 10, 8, 6, 4, 2, 1}
+
+// handlePoints 处理 points 命令：points <考试csv文件>...
+// 把当前名单当作最近一场考试，再读入若干历史考试csv文件，按F1积分榜规则
+// 把每场考试的名次换算成积分、按考号累加，最终按总积分排出一份综合积分榜
+func handlePoints(store *Store, args []string) {
+	if len(args) == 0 {
+		fmt.Println("用法: points <考试csv文件>...  把当前名单和这些历史考试按F1积分规则合并排名")
+		return
+	}
+
+	currentStudents := store.Students()
+	races := [][]Student{currentStudents}
+
+	for _, path := range args {
+		race, err := readCSVFile(path)
+		if err != nil {
+			fmt.Printf("读取 %s 失败: %v\n", path, err)
+			return
+		}
+		races = append(races, race)
+	}
+
+	strategy := PointsTableStrategy{Points: defaultF1Points}
+	ranked := strategy.RankByPoints(currentStudents, races)
+
+	fmt.Println("\n积分榜排名:")
+	for _, student := range ranked {
+		fmt.Printf("第%d名 %s（考号 %s）累计积分 %d\n", student.Rank, student.Name, student.ExamID, student.TotalScore)
+	}
+}
+
+// handleRankBig 处理 rankbig 命令：rankbig <输入csv文件> <输出csv文件>
+// 跟 export 不同，这个命令不会先把整份输入读成一个切片再排序：它调用
+// RunCountingSortRanking 分两遍流式扫描输入文件，全程只保留一个按总分计数的直方图，
+// 给无法一次性装进内存的大文件（百万行级导出）提供一条真正增量消费的路径
+func handleRankBig(store *Store, args []string) {
+	if len(args) != 2 {
+		fmt.Println("用法: rankbig <输入csv文件> <输出csv文件>")
+		return
+	}
+
+	if err := RunCountingSortRanking(args[0], args[1], DefaultBatchSize); err != nil {
+		fmt.Printf("排名失败: %v\n", err)
+		return
+	}
+
+	fmt.Printf("已流式排名并写入 %s\n", args[1])
+}
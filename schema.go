@@ -0,0 +1,206 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// NewGaokaoSchema 描述新高考"3+1+2"模式的选科规则：
+// 从 PrimarySubjects 中必选一科（物理/历史二选一），
+// 再从 SecondarySubjects 中选 SecondaryPickCount 科（化/生/政/地四选二）。
+type NewGaokaoSchema struct {
+	PrimarySubjects    []string `json:"primary_subjects"`
+	SecondarySubjects  []string `json:"secondary_subjects"`
+	SecondaryPickCount int      `json:"secondary_pick_count"`
+}
+
+// Schema 把原来散落在代码里的选科关键字、标识优先级、选科组合常量、
+// "自习"占位文案等硬编码内容收拢成一份可以从配置文件加载的结构体。
+type Schema struct {
+	// SubjectKeywords 是参与 assignSubjectsConcurrently 赋值流水线的选科关键字（单字），如 "物","化","生","政","史","地"
+	SubjectKeywords []string `json:"subject_keywords"`
+
+	// FlagOrder 定义 getFlagPriority 的优先级顺序，下标越小优先级越高；
+	// 同一项内用逗号分隔的多个标识视为同一优先级（如语数外赋值不区并列，但学科赋值时 "0" 和 "西" 同优先级）
+	FlagOrder []string `json:"flag_order"`
+
+	// SubjectFlagOrder 定义 getSubjectFlagPriority 的优先级顺序，规则同 FlagOrder
+	SubjectFlagOrder []string `json:"subject_flag_order"`
+
+	// SubjectCombinations 是 SubjectCombination 常量对应的合法选科组合文案
+	SubjectCombinations []string `json:"subject_combinations"`
+
+	// SelfStudyPlaceholder 是未选某学科的学生在该学科列上使用的占位文案前缀（原硬编码为"自习"）
+	SelfStudyPlaceholder string `json:"self_study_placeholder"`
+
+	// NewGaokao 非空时启用"3+1+2"模式的选科校验
+	NewGaokao *NewGaokaoSchema `json:"new_gaokao,omitempty"`
+}
+
+// activeSchema 是当前生效的配置，默认等价于重构前的硬编码行为，
+// main 在启动时可以用 LoadActiveSchema 从配置文件覆盖它
+var activeSchema = DefaultSchema()
+
+// DefaultSchema 返回与重构前硬编码完全一致的默认配置
+func DefaultSchema() *Schema {
+	return &Schema{
+		SubjectKeywords: []string{"物", "化", "生", "政", "史", "地"},
+		FlagOrder:       []string{"0", "西", "1", "2"},
+		SubjectFlagOrder: []string{"0,西", "1", "2"},
+		SubjectCombinations: []string{
+			string(PoliticsHistoryGeography),
+			string(PhysicsChemistryBiology),
+			string(PhysicsChemistryGeography),
+			string(PoliticsBiologyHistory),
+			string(PhysicsChemistryPolitics),
+			string(PoliticsBiologyGeography),
+			string(HistoryGeographyChemistry),
+			string(PhysicsChemistryHistory),
+			string(PhysicsPoliticsBiology),
+		},
+		SelfStudyPlaceholder: "自习",
+	}
+}
+
+// LoadSchema 从一份 JSON 配置文件加载 Schema
+func LoadSchema(path string) (*Schema, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("读取schema配置文件失败: %w", err)
+	}
+
+	var schema Schema
+	if err := json.Unmarshal(data, &schema); err != nil {
+		return nil, fmt.Errorf("解析schema配置文件失败: %w", err)
+	}
+
+	return &schema, nil
+}
+
+// LoadActiveSchema 加载配置文件并替换当前生效的 Schema；
+// 文件不存在时保留默认配置，不视为错误（允许工具在无配置文件时照常工作；这一点与 readCSVFile 不同，后者对不存在的文件返回错误）
+func LoadActiveSchema(path string) error {
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return nil
+	}
+
+	schema, err := LoadSchema(path)
+	if err != nil {
+		return err
+	}
+
+	activeSchema = schema
+	return nil
+}
+
+// priorityIndex 在有序的优先级分组（逗号分隔同优先级的多个标识）中查找 flag 的优先级，
+// 找不到时返回分组数量（排在最后）
+func priorityIndex(flag string, groups []string) int {
+	for i, group := range groups {
+		for _, candidate := range strings.Split(group, ",") {
+			if candidate == flag {
+				return i
+			}
+		}
+	}
+	return len(groups)
+}
+
+// getFlagPriority 获取标识的优先级，用于语数外赋值排序，规则来自 activeSchema.FlagOrder
+func getFlagPriority(flag string) int {
+	return priorityIndex(flag, activeSchema.FlagOrder)
+}
+
+// getSubjectFlagPriority 获取学科赋值排序时标识的优先级，规则来自 activeSchema.SubjectFlagOrder
+func getSubjectFlagPriority(flag string) int {
+	return priorityIndex(flag, activeSchema.SubjectFlagOrder)
+}
+
+// knownSubjectRunes 返回 activeSchema.SubjectKeywords 中出现过的全部字符（选科是按字符拼接的，如"物化生"）
+func knownSubjectRunes() map[rune]bool {
+	known := make(map[rune]bool)
+	for _, keyword := range activeSchema.SubjectKeywords {
+		for _, r := range keyword {
+			known[r] = true
+		}
+	}
+	return known
+}
+
+// ValidateSubjectCodes 校验每个学生的选科字符串里是否出现了 Schema 之外的未知科目代码，
+// 返回的每条信息都带上CSV行号（表头为第1行，第一个学生是第2行），方便定位问题数据
+func ValidateSubjectCodes(students []Student) []string {
+	known := knownSubjectRunes()
+
+	var problems []string
+	for i, student := range students {
+		rowNumber := i + 2
+		for _, r := range student.Subjects {
+			if !known[r] {
+				problems = append(problems, fmt.Sprintf("第%d行（考号 %s）选科 %q 中包含未知科目代码: %c", rowNumber, student.ExamID, student.Subjects, r))
+			}
+		}
+	}
+	return problems
+}
+
+// ValidateNewGaokaoSelection 在 activeSchema.NewGaokao 非空时，校验每个学生是否满足
+// "3+1+2"模式的选科要求：从 PrimarySubjects 中必选一科，从 SecondarySubjects 中选满 SecondaryPickCount 科。
+// activeSchema.NewGaokao 为 nil 时直接返回空结果，表示未启用新高考模式。
+func ValidateNewGaokaoSelection(students []Student) []string {
+	rule := activeSchema.NewGaokao
+	if rule == nil {
+		return nil
+	}
+
+	var problems []string
+	for i, student := range students {
+		rowNumber := i + 2
+
+		primaryCount := 0
+		for _, subject := range rule.PrimarySubjects {
+			if containsString(student.Subjects, subject) {
+				primaryCount++
+			}
+		}
+		if primaryCount != 1 {
+			problems = append(problems, fmt.Sprintf("第%d行（考号 %s）应从 %v 中必选一科，实际选中 %d 科", rowNumber, student.ExamID, rule.PrimarySubjects, primaryCount))
+		}
+
+		secondaryCount := 0
+		for _, subject := range rule.SecondarySubjects {
+			if containsString(student.Subjects, subject) {
+				secondaryCount++
+			}
+		}
+		if secondaryCount != rule.SecondaryPickCount {
+			problems = append(problems, fmt.Sprintf("第%d行（考号 %s）应从 %v 中选 %d 科，实际选中 %d 科", rowNumber, student.ExamID, rule.SecondarySubjects, rule.SecondaryPickCount, secondaryCount))
+		}
+	}
+
+	return problems
+}
+
+// ValidateSubjectCombinations 校验每个学生的选科是否是 activeSchema.SubjectCombinations
+// 里配置的合法组合之一。activeSchema.SubjectCombinations 为空时不做校验（等同于不限制组合）。
+func ValidateSubjectCombinations(students []Student) []string {
+	if len(activeSchema.SubjectCombinations) == 0 {
+		return nil
+	}
+
+	known := make(map[string]bool, len(activeSchema.SubjectCombinations))
+	for _, combination := range activeSchema.SubjectCombinations {
+		known[combination] = true
+	}
+
+	var problems []string
+	for i, student := range students {
+		rowNumber := i + 2
+		if !known[student.Subjects] {
+			problems = append(problems, fmt.Sprintf("第%d行（考号 %s）选科 %q 不是配置中的合法选科组合", rowNumber, student.ExamID, student.Subjects))
+		}
+	}
+	return problems
+}
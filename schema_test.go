@@ -0,0 +1,38 @@
+package main
+
+import "testing"
+
+// TestValidateSubjectCombinationsRejectsUnknownCombination 验证选科字符串不在
+// activeSchema.SubjectCombinations 配置的合法组合里时会被校验出来
+func TestValidateSubjectCombinationsRejectsUnknownCombination(t *testing.T) {
+	original := activeSchema
+	defer func() { activeSchema = original }()
+
+	activeSchema = &Schema{
+		SubjectCombinations: []string{"物化生", "政史地"},
+	}
+
+	students := []Student{
+		{ExamID: "1", Subjects: "物化生"},
+		{ExamID: "2", Subjects: "物化史"}, // 不在配置的合法组合里
+	}
+
+	problems := ValidateSubjectCombinations(students)
+	if len(problems) != 1 {
+		t.Fatalf("期望检出 1 条非法选科组合，实际: %+v", problems)
+	}
+}
+
+// TestValidateSubjectCombinationsSkippedWhenUnconfigured 验证不配置
+// SubjectCombinations 时不做任何校验，保持向后兼容
+func TestValidateSubjectCombinationsSkippedWhenUnconfigured(t *testing.T) {
+	original := activeSchema
+	defer func() { activeSchema = original }()
+
+	activeSchema = &Schema{}
+
+	students := []Student{{ExamID: "1", Subjects: "随便选的组合"}}
+	if problems := ValidateSubjectCombinations(students); len(problems) != 0 {
+		t.Fatalf("未配置 SubjectCombinations 时不应该报错，实际: %+v", problems)
+	}
+}
@@ -0,0 +1,165 @@
+package main
+
+import "sort"
+
+// ClassStat 记录某个班级的总分统计信息
+type ClassStat struct {
+	Class       string
+	Count       int
+	Average     float64
+	HighestName string
+	Highest     int
+	LowestName  string
+	Lowest      int
+}
+
+// SubjectStat 记录某个学科的高低分统计信息（仅统计选了该学科的学生）
+type SubjectStat struct {
+	Subject     string
+	Count       int
+	Average     float64
+	HighestName string
+	Highest     int
+	LowestName  string
+	Lowest      int
+}
+
+// analyzeClassStats 按班级统计总分的平均分/最高分/最低分
+func analyzeClassStats(students []Student) []ClassStat {
+	grouped := make(map[string][]Student)
+	for _, student := range students {
+		grouped[student.Class] = append(grouped[student.Class], student)
+	}
+
+	stats := make([]ClassStat, 0, len(grouped))
+	for class, group := range grouped {
+		stats = append(stats, buildClassStat(class, group))
+	}
+
+	sort.Slice(stats, func(i, j int) bool {
+		return stats[i].Class < stats[j].Class
+	})
+
+	return stats
+}
+
+// buildClassStat 汇总单个班级的统计信息
+func buildClassStat(class string, group []Student) ClassStat {
+	stat := ClassStat{Class: class, Count: len(group)}
+	if len(group) == 0 {
+		return stat
+	}
+
+	total := 0
+	stat.Highest, stat.Lowest = group[0].TotalScore, group[0].TotalScore
+	stat.HighestName, stat.LowestName = group[0].Name, group[0].Name
+
+	for _, student := range group {
+		total += student.TotalScore
+		if student.TotalScore > stat.Highest {
+			stat.Highest = student.TotalScore
+			stat.HighestName = student.Name
+		}
+		if student.TotalScore < stat.Lowest {
+			stat.Lowest = student.TotalScore
+			stat.LowestName = student.Name
+		}
+	}
+
+	stat.Average = float64(total) / float64(len(group))
+	return stat
+}
+
+// subjectScore 按学科关键字从选科字符串判断是否选了该学科（与 subjectAssigner 的分组口径一致），
+// 选了时统计用的分数固定使用总分
+func subjectScore(student Student, keyword string) (int, bool) {
+	if !containsString(student.Subjects, keyword) {
+		return 0, false
+	}
+	return student.TotalScore, true
+}
+
+// analyzeSubjectStats 按学科统计选科学生的总分平均分/最高分/最低分
+func analyzeSubjectStats(students []Student) []SubjectStat {
+	keywords := activeSchema.SubjectKeywords
+
+	stats := make([]SubjectStat, 0, len(keywords))
+	for _, keyword := range keywords {
+		stats = append(stats, buildSubjectStat(keyword, students))
+	}
+
+	return stats
+}
+
+// buildSubjectStat 汇总单个学科的统计信息
+func buildSubjectStat(keyword string, students []Student) SubjectStat {
+	stat := SubjectStat{Subject: keyword}
+
+	first := true
+	total := 0
+	for _, student := range students {
+		score, selected := subjectScore(student, keyword)
+		if !selected {
+			continue
+		}
+
+		stat.Count++
+		total += score
+		if first {
+			stat.Highest, stat.Lowest = score, score
+			stat.HighestName, stat.LowestName = student.Name, student.Name
+			first = false
+			continue
+		}
+		if score > stat.Highest {
+			stat.Highest = score
+			stat.HighestName = student.Name
+		}
+		if score < stat.Lowest {
+			stat.Lowest = score
+			stat.LowestName = student.Name
+		}
+	}
+
+	if stat.Count > 0 {
+		stat.Average = float64(total) / float64(stat.Count)
+	}
+	return stat
+}
+
+// classRankWithinGrade 计算每个学生在自己班级内，按总分排序后的名次（班级内排名）
+func classRankWithinGrade(students []Student) map[string]int {
+	grouped := make(map[string][]Student)
+	for _, student := range students {
+		grouped[student.Class] = append(grouped[student.Class], student)
+	}
+
+	classRank := make(map[string]int, len(students))
+	for _, group := range grouped {
+		sorted := SortStudentsForRank(group)
+		for i, student := range sorted {
+			classRank[student.ExamID] = i + 1
+		}
+	}
+
+	return classRank
+}
+
+// topNPerClass 取每个班级总分排名前 n 的学生，对应"每个班级前三名"这类查询
+func topNPerClass(students []Student, n int) map[string][]Student {
+	grouped := make(map[string][]Student)
+	for _, student := range students {
+		grouped[student.Class] = append(grouped[student.Class], student)
+	}
+
+	top := make(map[string][]Student, len(grouped))
+	for class, group := range grouped {
+		sorted := SortStudentsForRank(group)
+		if len(sorted) > n {
+			sorted = sorted[:n]
+		}
+		top[class] = sorted
+	}
+
+	return top
+}
@@ -0,0 +1,38 @@
+package main
+
+import "testing"
+
+// TestAnalyzeSubjectStatsUsesActiveSchema 验证 analyzeSubjectStats 读取的是
+// activeSchema.SubjectKeywords，而不是硬编码的学科列表：换一份只认"物"和"技"的
+// schema 后，统计结果应该只包含这两项，既不会漏掉新增的"技"，也不会继续统计被移除的"化"
+func TestAnalyzeSubjectStatsUsesActiveSchema(t *testing.T) {
+	original := activeSchema
+	defer func() { activeSchema = original }()
+
+	activeSchema = &Schema{
+		SubjectKeywords: []string{"物", "技"},
+	}
+
+	students := []Student{
+		{ExamID: "1", Subjects: "物化", TotalScore: 500, Name: "甲"},
+		{ExamID: "2", Subjects: "技物", TotalScore: 480, Name: "乙"},
+	}
+
+	stats := analyzeSubjectStats(students)
+
+	if len(stats) != 2 {
+		t.Fatalf("期望返回 2 个学科的统计，实际返回 %d 个: %+v", len(stats), stats)
+	}
+
+	seen := make(map[string]bool, len(stats))
+	for _, stat := range stats {
+		seen[stat.Subject] = true
+	}
+
+	if !seen["物"] || !seen["技"] {
+		t.Fatalf("期望统计包含配置中的 物/技，实际: %+v", stats)
+	}
+	if seen["化"] {
+		t.Fatalf("不应统计已从schema中移除的 化，实际: %+v", stats)
+	}
+}
@@ -0,0 +1,226 @@
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+// Store 是学生数据的内存索引，支持按考号/姓名/班级快速查找，
+// 并在每次增删改之后负责把 students.csv 重新落盘。
+type Store struct {
+	filePath string
+
+	byExamID map[string]*Student // key: 考号，唯一索引
+	byName   map[string][]*Student // key: 学生姓名，一个姓名可能对应多个学生
+	byClass  map[string][]*Student // key: 班级
+
+	order []string // 按插入顺序记录考号，保证导出/遍历时顺序稳定
+
+	nextID int // 下一个新增学生可用的序号，保证 ID 在增删之后仍然唯一
+
+	rankingStrategy RankingStrategy // Rebuild 用哪种并列规则给 Rank 字段编号，默认等价于原来的 CalculateRank
+}
+
+// NewStore 根据已读取的学生列表构建索引
+func NewStore(students []Student, filePath string) *Store {
+	store := &Store{
+		filePath:        filePath,
+		byExamID:        make(map[string]*Student),
+		byName:          make(map[string][]*Student),
+		byClass:         make(map[string][]*Student),
+		nextID:          1,
+		rankingStrategy: OrdinalRankingStrategy{},
+	}
+
+	for i := range students {
+		store.index(&students[i])
+		if students[i].ID >= store.nextID {
+			store.nextID = students[i].ID + 1
+		}
+	}
+
+	return store
+}
+
+// NextID 返回下一个可用的学生序号（ID），并把内部计数器推进一位。
+// 用于新增学生时分配序号：与"当前切片长度+1"不同，序号不会因为删除操作而与已有学生重复。
+func (s *Store) NextID() int {
+	id := s.nextID
+	s.nextID++
+	return id
+}
+
+// index 把一条学生记录登记到各级索引中
+func (s *Store) index(student *Student) {
+	s.byExamID[student.ExamID] = student
+	s.byName[student.Name] = append(s.byName[student.Name], student)
+	s.byClass[student.Class] = append(s.byClass[student.Class], student)
+	s.order = append(s.order, student.ExamID)
+}
+
+// Students 按插入顺序返回当前索引中的全部学生（值拷贝）
+func (s *Store) Students() []Student {
+	students := make([]Student, 0, len(s.order))
+	for _, examID := range s.order {
+		if student, ok := s.byExamID[examID]; ok {
+			students = append(students, *student)
+		}
+	}
+	return students
+}
+
+// Insert 新增一条学生记录，考号重复时返回错误
+func (s *Store) Insert(student Student) error {
+	if _, exists := s.byExamID[student.ExamID]; exists {
+		return fmt.Errorf("考号 %s 已存在", student.ExamID)
+	}
+
+	copied := student
+	s.index(&copied)
+	return s.Save()
+}
+
+// Update 按考号定位学生后应用 mutate 修改字段，找不到时返回错误。
+// mutate 如果修改了 ExamID（考号本身也是可以改的字段），Update 会把 byExamID
+// 索引和 order 中记录的考号一起迁移到新值，避免索引跟实际考号对不上。
+func (s *Store) Update(examID string, mutate func(*Student)) error {
+	student, ok := s.byExamID[examID]
+	if !ok {
+		return fmt.Errorf("未找到考号为 %s 的学生", examID)
+	}
+
+	// 姓名/班级可能被修改，更新前先从二级索引中摘除旧值
+	s.removeFromSecondaryIndexes(student)
+	oldExamID := student.ExamID
+	mutate(student)
+
+	if student.ExamID != oldExamID {
+		if _, exists := s.byExamID[student.ExamID]; exists {
+			// 新考号已被占用，回滚考号变更，其余字段的修改予以保留
+			attemptedExamID := student.ExamID
+			student.ExamID = oldExamID
+			s.byName[student.Name] = append(s.byName[student.Name], student)
+			s.byClass[student.Class] = append(s.byClass[student.Class], student)
+			return fmt.Errorf("考号 %s 已存在，无法修改为该考号", attemptedExamID)
+		}
+
+		delete(s.byExamID, oldExamID)
+		s.byExamID[student.ExamID] = student
+
+		for i, id := range s.order {
+			if id == oldExamID {
+				s.order[i] = student.ExamID
+				break
+			}
+		}
+	}
+
+	s.byName[student.Name] = append(s.byName[student.Name], student)
+	s.byClass[student.Class] = append(s.byClass[student.Class], student)
+
+	return s.Save()
+}
+
+// Delete 按考号删除一条学生记录
+func (s *Store) Delete(examID string) error {
+	student, ok := s.byExamID[examID]
+	if !ok {
+		return fmt.Errorf("未找到考号为 %s 的学生", examID)
+	}
+
+	s.removeFromSecondaryIndexes(student)
+	delete(s.byExamID, examID)
+
+	for i, id := range s.order {
+		if id == examID {
+			s.order = append(s.order[:i], s.order[i+1:]...)
+			break
+		}
+	}
+
+	return s.Save()
+}
+
+// removeFromSecondaryIndexes 把学生从姓名/班级索引中摘除
+func (s *Store) removeFromSecondaryIndexes(student *Student) {
+	s.byName[student.Name] = removeStudent(s.byName[student.Name], student.ExamID)
+	s.byClass[student.Class] = removeStudent(s.byClass[student.Class], student.ExamID)
+}
+
+// removeStudent 从切片中移除指定考号的学生指针
+func removeStudent(students []*Student, examID string) []*Student {
+	result := students[:0]
+	for _, student := range students {
+		if student.ExamID != examID {
+			result = append(result, student)
+		}
+	}
+	return result
+}
+
+// FindByExamID 按考号精确查找
+func (s *Store) FindByExamID(examID string) (Student, bool) {
+	student, ok := s.byExamID[examID]
+	if !ok {
+		return Student{}, false
+	}
+	return *student, true
+}
+
+// FindByName 按姓名查找，可能返回多条（重名）
+func (s *Store) FindByName(name string) []Student {
+	matches := s.byName[name]
+	students := make([]Student, 0, len(matches))
+	for _, student := range matches {
+		students = append(students, *student)
+	}
+	return students
+}
+
+// FindByClass 按班级查找
+func (s *Store) FindByClass(class string) []Student {
+	matches := s.byClass[class]
+	students := make([]Student, 0, len(matches))
+	for _, student := range matches {
+		students = append(students, *student)
+	}
+	return students
+}
+
+// SetRankingStrategy 设置 Rebuild 使用的并列排名规则（ordinal/dense/standard/fractional）
+func (s *Store) SetRankingStrategy(strategy RankingStrategy) {
+	s.rankingStrategy = strategy
+}
+
+// Rebuild 重新跑一遍排名/选科赋值流水线，并把结果写回索引
+func (s *Store) Rebuild() []Student {
+	students := s.Students()
+
+	rankSortedStudents := SortStudentsForRank(students)
+	rankedStudents := s.rankingStrategy.AssignRanks(rankSortedStudents)
+	rankedStudents = assignAllSubjects(students, rankedStudents)
+
+	for i := range rankedStudents {
+		if student, ok := s.byExamID[rankedStudents[i].ExamID]; ok {
+			*student = rankedStudents[i]
+		}
+	}
+
+	return s.Students()
+}
+
+// Save 将当前索引中的全部学生原子性地重写到 students.csv：
+// 先写入临时文件，再通过 rename 替换目标文件，避免写到一半被中断导致数据损坏
+func (s *Store) Save() error {
+	tmpFile := s.filePath + ".tmp"
+
+	if err := writeResultFile(s.Students(), tmpFile); err != nil {
+		return fmt.Errorf("写入临时文件失败: %w", err)
+	}
+
+	if err := os.Rename(tmpFile, s.filePath); err != nil {
+		return fmt.Errorf("替换 %s 失败: %w", s.filePath, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func newTestStore(t *testing.T, students []Student) *Store {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "students.csv")
+	return NewStore(students, path)
+}
+
+// TestStoreUpdateRekeysExamID 验证修改 ExamID 之后，byExamID 索引会跟着迁移到新考号，
+// 而不是留在旧考号下导致用新考号查不到、用旧考号又能查到一条"幽灵"记录
+func TestStoreUpdateRekeysExamID(t *testing.T) {
+	store := newTestStore(t, []Student{
+		{ID: 1, ExamID: "A1", Name: "甲", Class: "1班", TotalScore: 500},
+	})
+
+	if err := store.Update("A1", func(student *Student) {
+		student.ExamID = "A2"
+	}); err != nil {
+		t.Fatalf("更新考号失败: %v", err)
+	}
+
+	if _, ok := store.FindByExamID("A2"); !ok {
+		t.Fatalf("更新后应该能用新考号 A2 查到学生")
+	}
+	if _, ok := store.FindByExamID("A1"); ok {
+		t.Fatalf("更新后不应该再用旧考号 A1 查到学生")
+	}
+
+	students := store.Students()
+	if len(students) != 1 || students[0].ExamID != "A2" {
+		t.Fatalf("Students() 应该只返回一条考号为 A2 的记录，实际: %+v", students)
+	}
+}
+
+// TestStoreUpdateRejectsDuplicateExamID 验证把考号改成另一个已存在的考号时会被拒绝，
+// 并且原考号的索引保持不变
+func TestStoreUpdateRejectsDuplicateExamID(t *testing.T) {
+	store := newTestStore(t, []Student{
+		{ID: 1, ExamID: "A1", Name: "甲", TotalScore: 500},
+		{ID: 2, ExamID: "A2", Name: "乙", TotalScore: 480},
+	})
+
+	err := store.Update("A1", func(student *Student) {
+		student.ExamID = "A2"
+	})
+	if err == nil {
+		t.Fatalf("把考号改成已存在的考号应该返回错误")
+	}
+
+	if _, ok := store.FindByExamID("A1"); !ok {
+		t.Fatalf("更新失败后，旧考号 A1 应该仍然可以查到")
+	}
+}
+
+// TestStoreInsertAfterDeleteDoesNotReuseID 验证新增学生时序号由 Store.NextID 分配，
+// 不会在删除学生之后把"当前人数+1"复用成一个已存在的 ID
+func TestStoreInsertAfterDeleteDoesNotReuseID(t *testing.T) {
+	store := newTestStore(t, nil)
+
+	for _, examID := range []string{"A1", "A2", "A3"} {
+		if err := store.Insert(Student{ID: store.NextID(), ExamID: examID, TotalScore: 500}); err != nil {
+			t.Fatalf("新增 %s 失败: %v", examID, err)
+		}
+	}
+
+	if err := store.Delete("A2"); err != nil {
+		t.Fatalf("删除 A2 失败: %v", err)
+	}
+
+	newStudent := Student{ID: store.NextID(), ExamID: "A4", TotalScore: 500}
+	if err := store.Insert(newStudent); err != nil {
+		t.Fatalf("新增 A4 失败: %v", err)
+	}
+
+	ids := make(map[int]string)
+	for _, student := range store.Students() {
+		if existing, dup := ids[student.ID]; dup {
+			t.Fatalf("序号 %d 同时被 %s 和 %s 使用，应该保持唯一", student.ID, existing, student.ExamID)
+		}
+		ids[student.ID] = student.ExamID
+	}
+}
@@ -0,0 +1,270 @@
+package main
+
+import (
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+)
+
+// DefaultBatchSize 是流式读取时每批次的默认学生数量
+const DefaultBatchSize = 1000
+
+// expectedHeader 是 students.csv 的表头，readCSVFile 和流式读取共用同一份校验规则
+var expectedHeader = []string{"序号", "选科", "考号", "学生姓名", "班级", "总分", "排名", "语数外", "物理", "化学", "生物", "政治", "历史", "地理", "标识"}
+
+// parseStudentRow 把一行CSV数据解析成 Student，readCSVFile 和流式读取共用同一份解析逻辑
+func parseStudentRow(row []string) Student {
+	return Student{
+		ID:                 parseInt(row[0]),
+		Subjects:           row[1],
+		ExamID:             row[2],
+		Name:               row[3],
+		Class:              row[4],
+		TotalScore:         parseInt(row[5]),
+		Rank:               parseInt(row[6]),
+		ChineseMathEnglish: parseInt(row[7]),
+		Physics:            row[8],
+		Chemistry:          row[9],
+		Biology:            row[10],
+		Politics:           row[11],
+		History:            row[12],
+		Geography:          row[13],
+		Flag:               row[14],
+	}
+}
+
+// streamCSVFile 用 bufio.Reader + csv.Reader.Read() 逐行读取文件，
+// 每攒够 batchSize 条学生就推入 batches channel，让下游可以边读边处理，
+// 不必像 readCSVFile 那样把整份文件先缓冲进一个切片。
+// 读取结束或出错后都会关闭 batches 并把错误（如果有）发到 errs。
+func streamCSVFile(filePath string, batchSize int, batches chan<- []Student, errs chan<- error) {
+	defer close(batches)
+
+	file, err := os.Open(filePath)
+	if err != nil {
+		errs <- err
+		return
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(bufio.NewReader(file))
+
+	header, err := reader.Read()
+	if err != nil {
+		errs <- err
+		return
+	}
+	if len(header) != len(expectedHeader) {
+		errs <- fmt.Errorf("表头长度不匹配，期望 %d 列，实际 %d 列", len(expectedHeader), len(header))
+		return
+	}
+
+	batch := make([]Student, 0, batchSize)
+	for {
+		row, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			errs <- err
+			return
+		}
+
+		batch = append(batch, parseStudentRow(row))
+		if len(batch) >= batchSize {
+			batches <- batch
+			batch = make([]Student, 0, batchSize)
+		}
+	}
+
+	if len(batch) > 0 {
+		batches <- batch
+	}
+
+	errs <- nil
+}
+
+// ReadCSVBatches 以 batchSize 为单位流式读取 students.csv，返回一个学生批次 channel，
+// 供下游的排名/赋值流程增量消费，适合无法一次性装入内存的百万行级导出文件。
+func ReadCSVBatches(filePath string, batchSize int) (<-chan []Student, <-chan error) {
+	batches := make(chan []Student)
+	errs := make(chan error, 1)
+
+	go streamCSVFile(filePath, batchSize, batches, errs)
+
+	return batches, errs
+}
+
+// readCSVFile 读取CSV文件并返回学生信息列表。
+// 为了兼容现有调用方（main 等只需要一次性拿到完整切片的场景），
+// 这里只是在 ReadCSVBatches 之上做了一层"攒成一个切片"的薄封装。
+func readCSVFile(filePath string) ([]Student, error) {
+	batches, errs := ReadCSVBatches(filePath, DefaultBatchSize)
+
+	var students []Student
+	for batch := range batches {
+		students = append(students, batch...)
+	}
+
+	if err := <-errs; err != nil {
+		return nil, err
+	}
+
+	return students, nil
+}
+
+// CalculateRankByCountingSort 是 CalculateRank 的两遍计数排序版本：
+// 第一遍按总分建立直方图（总分值域通常远小于学生数），第二遍按分数从高到低、
+// 同分内按ID升序遍历直方图桶直接产出排名结果，避免对全量数据做 O(n log n) 的比较排序，
+// 适合百万行级输入。结果与 CalculateRank(SortStudentsForRank(students)) 等价。
+func CalculateRankByCountingSort(students []Student) []Student {
+	if len(students) == 0 {
+		return nil
+	}
+
+	minScore, maxScore := students[0].TotalScore, students[0].TotalScore
+	for _, student := range students {
+		if student.TotalScore < minScore {
+			minScore = student.TotalScore
+		}
+		if student.TotalScore > maxScore {
+			maxScore = student.TotalScore
+		}
+	}
+
+	buckets := make([][]Student, maxScore-minScore+1)
+	for _, student := range students {
+		idx := student.TotalScore - minScore
+		buckets[idx] = append(buckets[idx], student)
+	}
+
+	ranked := make([]Student, 0, len(students))
+	rank := 1
+	for score := maxScore; score >= minScore; score-- {
+		bucket := buckets[score-minScore]
+		if len(bucket) == 0 {
+			continue
+		}
+
+		// 同分的学生按ID升序排列，确保稳定性，与 SortStudentsForRank 的并列规则保持一致
+		sort.Slice(bucket, func(i, j int) bool {
+			return bucket[i].ID < bucket[j].ID
+		})
+
+		for _, student := range bucket {
+			student.Rank = rank
+			ranked = append(ranked, student)
+			rank++
+		}
+	}
+
+	return ranked
+}
+
+// RunCountingSortRanking 是 CalculateRankByCountingSort 的流式落地版本：分两遍读取
+// inputPath，全程只在内存里保留一个按总分计数的直方图，不会像 readCSVFile 那样先把
+// 全部学生攒成一个切片，真正做到百万行级输入也能跑。结果写入 outputPath，行格式与
+// writeResultFile 一致，可以直接当 students.csv 使用。
+//
+// 第一遍只统计每个总分出现的次数；第二遍重新扫描一次文件，按总分从高到低、同分内
+// 按ID升序（与 CalculateRankByCountingSort 的并列规则一致）给每条记录编号后直接写出，
+// 要求输入文件内各总分分组中的记录已经按ID升序出现（CalculateRankByCountingSort 会在
+// 桶内自行按ID排序，流式版本不保留桶，所以无法这样做）。第二遍扫描时会校验这个前提：
+// 一旦发现同一总分内出现ID乱序，立即报错，而不是静默产出错误的并列名次。
+func RunCountingSortRanking(inputPath, outputPath string, batchSize int) error {
+	minScore, maxScore, histogram, err := buildScoreHistogram(inputPath, batchSize)
+	if err != nil {
+		return err
+	}
+	if len(histogram) == 0 {
+		return writeResultFile(nil, outputPath)
+	}
+
+	rankStart := make(map[int]int, len(histogram))
+	rank := 1
+	for score := maxScore; score >= minScore; score-- {
+		count, ok := histogram[score]
+		if !ok {
+			continue
+		}
+		rankStart[score] = rank
+		rank += count
+	}
+
+	return writeRankedStudents(inputPath, outputPath, batchSize, rankStart)
+}
+
+// buildScoreHistogram 是 RunCountingSortRanking 的第一遍扫描：流式统计每个总分出现的
+// 次数以及总分的值域，不保留任何一条完整的学生记录。
+func buildScoreHistogram(inputPath string, batchSize int) (minScore, maxScore int, histogram map[int]int, err error) {
+	histogram = make(map[int]int)
+	first := true
+
+	batches, errs := ReadCSVBatches(inputPath, batchSize)
+	for batch := range batches {
+		for _, student := range batch {
+			histogram[student.TotalScore]++
+			if first {
+				minScore, maxScore = student.TotalScore, student.TotalScore
+				first = false
+				continue
+			}
+			if student.TotalScore < minScore {
+				minScore = student.TotalScore
+			}
+			if student.TotalScore > maxScore {
+				maxScore = student.TotalScore
+			}
+		}
+	}
+
+	if err := <-errs; err != nil {
+		return 0, 0, nil, err
+	}
+
+	return minScore, maxScore, histogram, nil
+}
+
+// writeRankedStudents 是 RunCountingSortRanking 的第二遍扫描：按 rankStart 里记录的
+// 每个总分对应的起始名次，边流式读入边给每条记录分配排名并写出，同样不保留完整切片。
+func writeRankedStudents(inputPath, outputPath string, batchSize int, rankStart map[int]int) error {
+	file, err := os.Create(outputPath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	writer := csv.NewWriter(file)
+	defer writer.Flush()
+
+	header := append([]string(nil), expectedHeader...)
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+
+	seen := make(map[int]int, len(rankStart))
+	lastID := make(map[int]int, len(rankStart))
+
+	batches, errs := ReadCSVBatches(inputPath, batchSize)
+	for batch := range batches {
+		for _, student := range batch {
+			if count := seen[student.TotalScore]; count > 0 && student.ID < lastID[student.TotalScore] {
+				return fmt.Errorf("总分 %d 的记录未按ID升序排列（考号 %s 的ID %d 小于前一条的ID %d），"+
+					"流式计数排序要求同分学生已按ID升序出现，请先对输入文件排序", student.TotalScore, student.ExamID, student.ID, lastID[student.TotalScore])
+			}
+			lastID[student.TotalScore] = student.ID
+
+			student.Rank = rankStart[student.TotalScore] + seen[student.TotalScore]
+			seen[student.TotalScore]++
+
+			if err := writer.Write(studentRow(student)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return <-errs
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+// writeTestCSV 把学生写成一份符合 expectedHeader 格式的CSV文件，供流式读取测试使用
+func writeTestCSV(t *testing.T, students []Student) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "students.csv")
+	if err := writeResultFile(students, path); err != nil {
+		t.Fatalf("写入测试CSV失败: %v", err)
+	}
+	return path
+}
+
+// TestRunCountingSortRankingMatchesInMemoryVersion 验证流式两遍计数排序给每条记录算出
+// 的 Rank，跟先读入内存再调用 CalculateRankByCountingSort 算出的 Rank 完全一致。
+// RunCountingSortRanking 按输入文件的原始顺序写出（只是补上 Rank 字段），不会像
+// CalculateRankByCountingSort 那样把结果重新排序成按名次输出的切片——如果重新排序，
+// 就得先把全部记录攒在内存里，违背了流式处理的目的，所以这里按考号比较 Rank 而不是按行号比较
+func TestRunCountingSortRankingMatchesInMemoryVersion(t *testing.T) {
+	students := []Student{
+		{ID: 1, ExamID: "A1", Name: "甲", TotalScore: 600},
+		{ID: 2, ExamID: "A2", Name: "乙", TotalScore: 580},
+		{ID: 3, ExamID: "A3", Name: "丙", TotalScore: 580},
+		{ID: 4, ExamID: "A4", Name: "丁", TotalScore: 610},
+		{ID: 5, ExamID: "A5", Name: "戊", TotalScore: 550},
+	}
+
+	inputPath := writeTestCSV(t, students)
+	outputPath := filepath.Join(t.TempDir(), "ranked.csv")
+
+	if err := RunCountingSortRanking(inputPath, outputPath, 2); err != nil {
+		t.Fatalf("RunCountingSortRanking 失败: %v", err)
+	}
+
+	got, err := readCSVFile(outputPath)
+	if err != nil {
+		t.Fatalf("读取排名结果失败: %v", err)
+	}
+
+	want := CalculateRankByCountingSort(students)
+	wantRankByExamID := make(map[string]int, len(want))
+	for _, student := range want {
+		wantRankByExamID[student.ExamID] = student.Rank
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("结果数量不一致，期望 %d 条，实际 %d 条", len(want), len(got))
+	}
+	for _, student := range got {
+		if student.Rank != wantRankByExamID[student.ExamID] {
+			t.Fatalf("考号 %s 排名不一致，期望 %d，实际 %d", student.ExamID, wantRankByExamID[student.ExamID], student.Rank)
+		}
+	}
+}
+
+// TestRunCountingSortRankingRejectsOutOfOrderTies 验证同一总分内的记录不是按ID升序出现时，
+// RunCountingSortRanking 会报错而不是静默产出错误的并列名次
+func TestRunCountingSortRankingRejectsOutOfOrderTies(t *testing.T) {
+	students := []Student{
+		{ID: 1, ExamID: "A1", Name: "甲", TotalScore: 600},
+		{ID: 3, ExamID: "A3", Name: "丙", TotalScore: 580}, // 与下一条同分，但ID乱序出现在前面
+		{ID: 2, ExamID: "A2", Name: "乙", TotalScore: 580},
+		{ID: 4, ExamID: "A4", Name: "丁", TotalScore: 610},
+		{ID: 5, ExamID: "A5", Name: "戊", TotalScore: 550},
+	}
+
+	inputPath := writeTestCSV(t, students)
+	outputPath := filepath.Join(t.TempDir(), "ranked.csv")
+
+	err := RunCountingSortRanking(inputPath, outputPath, 2)
+	if err == nil {
+		t.Fatalf("同分记录ID乱序时应该报错，而不是静默产出错误的并列名次")
+	}
+}
+
+// TestRunCountingSortRankingEmptyInput 验证空输入（只有表头）不会报错，并写出一份只有表头的文件
+func TestRunCountingSortRankingEmptyInput(t *testing.T) {
+	inputPath := writeTestCSV(t, nil)
+	outputPath := filepath.Join(t.TempDir(), "ranked.csv")
+
+	if err := RunCountingSortRanking(inputPath, outputPath, 2); err != nil {
+		t.Fatalf("RunCountingSortRanking 失败: %v", err)
+	}
+
+	got, err := readCSVFile(outputPath)
+	if err != nil {
+		t.Fatalf("读取排名结果失败: %v", err)
+	}
+	if len(got) != 0 {
+		t.Fatalf("空输入应该得到空结果，实际: %+v", got)
+	}
+}